@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/GergJack/JackHenryProject/middleware"
+)
+
+// AlertsResponse NWS active alerts API structs - only including fields we actually use
+type AlertsResponse struct {
+	Features []AlertFeature `json:"features"`
+}
+
+type AlertFeature struct {
+	Properties AlertProperties `json:"properties"`
+}
+
+// AlertProperties NWS has a lot more fields (areaDesc, sent, sender, etc.) but we only need these
+type AlertProperties struct {
+	Event       string    `json:"event"`
+	Severity    string    `json:"severity"`
+	Description string    `json:"description"`
+	Instruction string    `json:"instruction"`
+	Effective   time.Time `json:"effective"`
+	Expires     time.Time `json:"expires"`
+}
+
+// AlertSummary Simplified alert shape used both standalone and inlined into WeatherResponse
+type AlertSummary struct {
+	Event       string    `json:"event"`
+	Severity    string    `json:"severity"`
+	Description string    `json:"description"`
+	Instruction string    `json:"instruction,omitempty"`
+	Effective   time.Time `json:"effective"`
+	Expires     time.Time `json:"expires"`
+}
+
+// severityRank orders NWS severity levels from least to most severe so the
+// worst active alert can be picked out for a location
+var severityRank = map[string]int{
+	"Unknown":  0,
+	"Minor":    1,
+	"Moderate": 2,
+	"Severe":   3,
+	"Extreme":  4,
+}
+
+// alertsCacheTTL alerts can change quickly (new warnings issued, others
+// cancelled) so we only hold onto them briefly
+const alertsCacheTTL = 1 * time.Minute
+
+// GetAlerts hits the NWS active alerts endpoint for the given point. Cached
+// briefly and deduped via singleflight like the forecast calls.
+func (nws *NWSProvider) GetAlerts(ctx context.Context, lat, lon float64) ([]AlertSummary, error) {
+	alertsKey := cacheKey("alerts", lat, lon)
+	if cached, ok := nws.cache.Get(alertsKey); ok {
+		var alerts []AlertSummary
+		if err := json.Unmarshal(cached, &alerts); err == nil {
+			return alerts, nil
+		}
+	}
+
+	v, err, _ := nws.sf.Do(alertsKey, func() (interface{}, error) {
+		return nws.fetchAlerts(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.(*alertsResult)
+
+	if encoded, err := json.Marshal(result.alerts); err == nil {
+		nws.cache.Set(alertsKey, encoded, result.ttl)
+	}
+
+	return result.alerts, nil
+}
+
+// alertsResult bundles the parsed alerts with the TTL they should be cached for
+type alertsResult struct {
+	alerts []AlertSummary
+	ttl    time.Duration
+}
+
+func (nws *NWSProvider) fetchAlerts(ctx context.Context, lat, lon float64) (*alertsResult, error) {
+	defer observeUpstream("alerts")()
+
+	alertsURL := fmt.Sprintf("https://api.weather.gov/alerts/active?point=%.4f,%.4f", lat, lon)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", alertsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create alerts request: %w", err)
+	}
+
+	// NWS returns 403 without User-Agent
+	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
+
+	resp, err := nws.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch alerts data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newUpstreamError(resp.StatusCode, fmt.Errorf("NWS alerts API returned status %d", resp.StatusCode))
+	}
+
+	ttl := ttlFromHeaders(resp, alertsCacheTTL)
+
+	var alertsResp AlertsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&alertsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode alerts response: %w", err)
+	}
+
+	alerts := make([]AlertSummary, 0, len(alertsResp.Features))
+	for _, feature := range alertsResp.Features {
+		p := feature.Properties
+		alerts = append(alerts, AlertSummary{
+			Event:       p.Event,
+			Severity:    p.Severity,
+			Description: p.Description,
+			Instruction: p.Instruction,
+			Effective:   p.Effective,
+			Expires:     p.Expires,
+		})
+	}
+
+	// Worst alert first so callers can just take alerts[0] for the headline
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return severityRank[alerts[i].Severity] > severityRank[alerts[j].Severity]
+	})
+
+	return &alertsResult{alerts: alerts, ttl: ttl}, nil
+}
+
+// AlertsHandler handles HTTP requests for active alerts
+type AlertsHandler struct {
+	nws *NWSProvider
+}
+
+// NewAlertsHandler creates a new alerts handler
+func NewAlertsHandler(nws *NWSProvider) *AlertsHandler {
+	return &AlertsHandler{nws: nws}
+}
+
+// ServeHTTP handles the /alerts endpoint
+func (ah *AlertsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	if r.Method != http.MethodGet {
+		ah.sendError(w, http.StatusMethodNotAllowed, "Method not allowed", "Only GET supported")
+		return
+	}
+
+	if ah.nws == nil {
+		ah.sendError(w, http.StatusServiceUnavailable, "Not available", "Alerts require the NWS provider")
+		return
+	}
+
+	lat, lon, status, errTitle, errMsg, ok := parseLatLon(r)
+	if !ok {
+		ah.sendError(w, status, errTitle, errMsg)
+		return
+	}
+
+	alerts, err := ah.nws.GetAlerts(r.Context(), lat, lon)
+	if err != nil {
+		slog.Error("alerts request failed",
+			"request_id", reqID,
+			"lat", lat,
+			"lon", lon,
+			"upstream_status", upstreamStatus(err),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err,
+		)
+		ah.sendError(w, http.StatusInternalServerError, "API error", "Could not get alerts")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"location": Location{Lat: lat, Lng: lon},
+		"alerts":   alerts,
+	})
+
+	recordRequest("/alerts", http.StatusOK)
+	slog.Info("alerts request served",
+		"request_id", reqID,
+		"lat", lat,
+		"lon", lon,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// sendError writes an error response
+func (ah *AlertsHandler) sendError(w http.ResponseWriter, statusCode int, error, message string) {
+	recordRequest("/alerts", statusCode)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: error, Message: message})
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"container/list"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache is a small TTL key/value store. The default implementation is an
+// in-memory LRU, but this is kept as an interface so it can be swapped for a
+// shared cache (Redis, etc.) without touching the providers.
+type Cache interface {
+	Get(key string) (value []byte, ok bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// cacheEntry A cached value plus when it stops being valid
+type cacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// LRUCache in-memory cache with a max entry count and per-entry TTL
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewLRUCache creates an in-memory LRU cache that holds at most capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = 256
+	}
+	return &LRUCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key, or ok=false if it's missing or expired
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, found := c.items[key]
+	if !found {
+		return nil, false
+	}
+
+	entry := elem.Value.(*cacheEntry)
+	if time.Now().After(entry.expires) {
+		// Stale - evict it now rather than waiting for an eviction pass
+		c.ll.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	cacheHitsTotal.Inc()
+	return entry.value, true
+}
+
+// Set stores value under key for the given TTL, evicting the oldest entry if needed
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := time.Now().Add(ttl)
+
+	if elem, found := c.items[key]; found {
+		c.ll.MoveToFront(elem)
+		elem.Value.(*cacheEntry).value = value
+		elem.Value.(*cacheEntry).expires = expires
+		return
+	}
+
+	elem := c.ll.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+	c.items[key] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*cacheEntry).key)
+		}
+	}
+}
+
+// cacheKey rounds coordinates to 4 decimal places - matches the granularity of
+// the NWS /points grid, so nearby lookups share a cache entry
+func cacheKey(prefix string, lat, lon float64) string {
+	return prefix + ":" + strconv.FormatFloat(lat, 'f', 4, 64) + "," + strconv.FormatFloat(lon, 'f', 4, 64)
+}
+
+// ttlFromHeaders honors the upstream Cache-Control max-age or Expires header
+// when present, otherwise falls back to our own default TTL
+func ttlFromHeaders(resp *http.Response, fallback time.Duration) time.Duration {
+	if cc := resp.Header.Get("Cache-Control"); cc != "" {
+		for _, directive := range strings.Split(cc, ",") {
+			directive = strings.TrimSpace(directive)
+			if after, ok := strings.CutPrefix(directive, "max-age="); ok {
+				if secs, err := strconv.Atoi(after); err == nil && secs > 0 {
+					return time.Duration(secs) * time.Second
+				}
+			}
+		}
+	}
+
+	if expiresHdr := resp.Header.Get("Expires"); expiresHdr != "" {
+		if expires, err := http.ParseTime(expiresHdr); err == nil {
+			if ttl := time.Until(expires); ttl > 0 {
+				return ttl
+			}
+		}
+	}
+
+	return fallback
+}
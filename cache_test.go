@@ -0,0 +1,115 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUCacheGetSet(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, c *LRUCache)
+	}{
+		{
+			name: "missing key",
+			run: func(t *testing.T, c *LRUCache) {
+				if _, ok := c.Get("nope"); ok {
+					t.Fatal("expected ok=false for missing key")
+				}
+			},
+		},
+		{
+			name: "set then get",
+			run: func(t *testing.T, c *LRUCache) {
+				c.Set("a", []byte("1"), time.Minute)
+				value, ok := c.Get("a")
+				if !ok || string(value) != "1" {
+					t.Fatalf("got value=%q ok=%v, want value=1 ok=true", value, ok)
+				}
+			},
+		},
+		{
+			name: "expired entry is evicted on get",
+			run: func(t *testing.T, c *LRUCache) {
+				c.Set("a", []byte("1"), -time.Second)
+				if _, ok := c.Get("a"); ok {
+					t.Fatal("expected ok=false for expired entry")
+				}
+				if _, found := c.items["a"]; found {
+					t.Fatal("expected expired entry to be removed from items")
+				}
+			},
+		},
+		{
+			name: "set overwrites existing value and ttl",
+			run: func(t *testing.T, c *LRUCache) {
+				// Start already expired so the Get below only succeeds if the
+				// second Set also replaced the TTL
+				c.Set("a", []byte("1"), -time.Second)
+				c.Set("a", []byte("2"), time.Minute)
+				value, ok := c.Get("a")
+				if !ok || string(value) != "2" {
+					t.Fatalf("got value=%q ok=%v, want value=2 ok=true", value, ok)
+				}
+				if c.ll.Len() != 1 {
+					t.Fatalf("got %d entries, want 1 (overwrite shouldn't grow the list)", c.ll.Len())
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, NewLRUCache(10))
+		})
+	}
+}
+
+func TestLRUCacheEvictsOldestOverCapacity(t *testing.T) {
+	c := NewLRUCache(2)
+
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+	c.Set("c", []byte("3"), time.Minute)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected oldest entry \"a\" to have been evicted")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Fatal("expected \"b\" to still be cached")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected \"c\" to still be cached")
+	}
+}
+
+func TestLRUCacheGetRefreshesRecency(t *testing.T) {
+	c := NewLRUCache(2)
+
+	c.Set("a", []byte("1"), time.Minute)
+	c.Set("b", []byte("2"), time.Minute)
+
+	// Touch "a" so "b" becomes the least recently used entry
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected \"a\" to be cached")
+	}
+
+	c.Set("c", []byte("3"), time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected \"b\" to have been evicted as the least recently used entry")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected \"a\" to still be cached after being touched")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected \"c\" to still be cached")
+	}
+}
+
+func TestNewLRUCacheDefaultsCapacity(t *testing.T) {
+	c := NewLRUCache(0)
+	if c.capacity != 256 {
+		t.Fatalf("got capacity %d, want 256 for non-positive input", c.capacity)
+	}
+}
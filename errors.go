@@ -0,0 +1,31 @@
+package main
+
+import "errors"
+
+// upstreamError wraps an error from an upstream weather API with the HTTP
+// status code it returned, so handlers can log it without parsing the
+// error string
+type upstreamError struct {
+	status int
+	err    error
+}
+
+func (e *upstreamError) Error() string { return e.err.Error() }
+func (e *upstreamError) Unwrap() error { return e.err }
+
+// newUpstreamError wraps err with the upstream HTTP status code, recording
+// it against weather_upstream_errors_total along the way
+func newUpstreamError(status int, err error) error {
+	recordUpstreamError(status)
+	return &upstreamError{status: status, err: err}
+}
+
+// upstreamStatus extracts the HTTP status code from err if it is (or wraps)
+// an upstreamError, or 0 if there isn't one (e.g. a network-level failure)
+func upstreamStatus(err error) int {
+	var ue *upstreamError
+	if errors.As(err, &ue) {
+		return ue.status
+	}
+	return 0
+}
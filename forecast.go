@@ -0,0 +1,242 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/GergJack/JackHenryProject/middleware"
+)
+
+// Defaults when ?days= / ?hours= aren't supplied
+const (
+	defaultForecastDays  = 7
+	defaultForecastHours = 24
+)
+
+// ForecastPeriodResponse is the compact per-period shape returned by
+// /forecast and /forecast/hourly. Details is only populated with ?verbose=true.
+type ForecastPeriodResponse struct {
+	Name             string `json:"name"`
+	StartTime        string `json:"start_time"`
+	EndTime          string `json:"end_time"`
+	Temp             int    `json:"temp"`
+	TempType         string `json:"temp_type"`
+	TemperatureTrend string `json:"temperature_trend,omitempty"`
+	WindSpeed        string `json:"wind_speed"`
+	WindDirection    string `json:"wind_direction"`
+	Forecast         string `json:"forecast"`
+	Details          string `json:"details,omitempty"`
+}
+
+// MultiPeriodResponse is the shape returned by /forecast and /forecast/hourly
+type MultiPeriodResponse struct {
+	Location Location                 `json:"location"`
+	Units    string                   `json:"units"`
+	Periods  []ForecastPeriodResponse `json:"periods"`
+}
+
+// ForecastHandler handles HTTP requests for the multi-day forecast
+type ForecastHandler struct {
+	nws *NWSProvider
+}
+
+// NewForecastHandler creates a new multi-day forecast handler
+func NewForecastHandler(nws *NWSProvider) *ForecastHandler {
+	return &ForecastHandler{nws: nws}
+}
+
+// ServeHTTP handles the /forecast endpoint
+func (fh *ForecastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	if r.Method != http.MethodGet {
+		fh.sendError(w, http.StatusMethodNotAllowed, "Method not allowed", "Only GET supported")
+		return
+	}
+
+	if fh.nws == nil {
+		fh.sendError(w, http.StatusServiceUnavailable, "Not available", "Multi-day forecasts require the NWS provider")
+		return
+	}
+
+	lat, lon, status, errTitle, errMsg, ok := parseLatLon(r)
+	if !ok {
+		fh.sendError(w, status, errTitle, errMsg)
+		return
+	}
+
+	days := queryInt(r, "days", defaultForecastDays)
+	units := normalizeUnits(r.URL.Query().Get("units"))
+	verbose := r.URL.Query().Get("verbose") == "true"
+
+	periods, err := fh.nws.GetDailyPeriods(r.Context(), lat, lon)
+	if err != nil {
+		slog.Error("forecast request failed",
+			"request_id", reqID,
+			"lat", lat,
+			"lon", lon,
+			"upstream_status", upstreamStatus(err),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err,
+		)
+		fh.sendError(w, http.StatusInternalServerError, "API error", "Could not get forecast")
+		return
+	}
+
+	// Each day has a day + night period, so N days is 2N periods
+	periods = clampPeriods(periods, days*2)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MultiPeriodResponse{
+		Location: Location{Lat: lat, Lng: lon},
+		Units:    units,
+		Periods:  buildPeriodResponses(periods, units, verbose),
+	})
+
+	recordRequest("/forecast", http.StatusOK)
+	slog.Info("forecast request served",
+		"request_id", reqID,
+		"lat", lat,
+		"lon", lon,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// sendError writes an error response
+func (fh *ForecastHandler) sendError(w http.ResponseWriter, statusCode int, error, message string) {
+	recordRequest("/forecast", statusCode)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: error, Message: message})
+}
+
+// HourlyForecastHandler handles HTTP requests for the hour-by-hour forecast
+type HourlyForecastHandler struct {
+	nws *NWSProvider
+}
+
+// NewHourlyForecastHandler creates a new hourly forecast handler
+func NewHourlyForecastHandler(nws *NWSProvider) *HourlyForecastHandler {
+	return &HourlyForecastHandler{nws: nws}
+}
+
+// ServeHTTP handles the /forecast/hourly endpoint
+func (hh *HourlyForecastHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	if r.Method != http.MethodGet {
+		hh.sendError(w, http.StatusMethodNotAllowed, "Method not allowed", "Only GET supported")
+		return
+	}
+
+	if hh.nws == nil {
+		hh.sendError(w, http.StatusServiceUnavailable, "Not available", "Hourly forecasts require the NWS provider")
+		return
+	}
+
+	lat, lon, status, errTitle, errMsg, ok := parseLatLon(r)
+	if !ok {
+		hh.sendError(w, status, errTitle, errMsg)
+		return
+	}
+
+	hours := queryInt(r, "hours", defaultForecastHours)
+	units := normalizeUnits(r.URL.Query().Get("units"))
+	verbose := r.URL.Query().Get("verbose") == "true"
+
+	periods, err := hh.nws.GetHourlyPeriods(r.Context(), lat, lon)
+	if err != nil {
+		slog.Error("hourly forecast request failed",
+			"request_id", reqID,
+			"lat", lat,
+			"lon", lon,
+			"upstream_status", upstreamStatus(err),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err,
+		)
+		hh.sendError(w, http.StatusInternalServerError, "API error", "Could not get hourly forecast")
+		return
+	}
+
+	periods = clampPeriods(periods, hours)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MultiPeriodResponse{
+		Location: Location{Lat: lat, Lng: lon},
+		Units:    units,
+		Periods:  buildPeriodResponses(periods, units, verbose),
+	})
+
+	recordRequest("/forecast/hourly", http.StatusOK)
+	slog.Info("hourly forecast request served",
+		"request_id", reqID,
+		"lat", lat,
+		"lon", lon,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
+
+// sendError writes an error response
+func (hh *HourlyForecastHandler) sendError(w http.ResponseWriter, statusCode int, error, message string) {
+	recordRequest("/forecast/hourly", statusCode)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: error, Message: message})
+}
+
+// queryInt reads an int query param, falling back to fallback if it's
+// missing or not a positive integer
+func queryInt(r *http.Request, name string, fallback int) int {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// clampPeriods caps periods to the first limit entries
+func clampPeriods(periods []ForecastPeriod, limit int) []ForecastPeriod {
+	if limit <= 0 || limit >= len(periods) {
+		return periods
+	}
+	return periods[:limit]
+}
+
+// buildPeriodResponses converts raw NWS periods into the compact response
+// shape, honoring units and verbose
+func buildPeriodResponses(periods []ForecastPeriod, units string, verbose bool) []ForecastPeriodResponse {
+	out := make([]ForecastPeriodResponse, 0, len(periods))
+	for _, period := range periods {
+		tempF := period.Temperature
+		if period.TemperatureUnit == "C" {
+			tempF = int(float64(tempF)*9/5 + 32)
+		}
+
+		resp := ForecastPeriodResponse{
+			Name:             period.Name,
+			StartTime:        period.StartTime,
+			EndTime:          period.EndTime,
+			Temp:             convertTemp(tempF, units),
+			TempType:         getTempType(float64(tempF)),
+			TemperatureTrend: period.TemperatureTrend,
+			WindSpeed:        convertWindSpeed(period.WindSpeed, units),
+			WindDirection:    period.WindDirection,
+			Forecast:         period.ShortForecast,
+		}
+		if verbose {
+			resp.Details = period.DetailedForecast
+		}
+
+		out = append(out, resp)
+	}
+	return out
+}
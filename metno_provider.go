@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// metnoForecastCacheTTL fallback TTL when met.no doesn't send an Expires
+// header - their own docs say to expect a new forecast every couple of hours
+const metnoForecastCacheTTL = 30 * time.Minute
+
+// metnoResponse met.no Locationforecast/2.0 compact API - only including
+// fields we actually use
+type metnoResponse struct {
+	Properties struct {
+		Timeseries []metnoTimeseriesEntry `json:"timeseries"`
+	} `json:"properties"`
+}
+
+// metnoTimeseriesEntry is one timestamped entry in the timeseries - met.no
+// reports data at fixed points in time rather than a single "current
+// conditions" entry, so GetForecast picks the entry closest to now itself.
+type metnoTimeseriesEntry struct {
+	Time time.Time `json:"time"`
+	Data struct {
+		Instant struct {
+			Details struct {
+				AirTemperature float64 `json:"air_temperature"`
+			} `json:"details"`
+		} `json:"instant"`
+		Next1Hours struct {
+			Summary struct {
+				SymbolCode string `json:"symbol_code"`
+			} `json:"summary"`
+		} `json:"next_1_hours"`
+	} `json:"data"`
+}
+
+// MetNoProvider implements Provider on top of the Norwegian Meteorological
+// Institute's Locationforecast API (api.met.no). No API key needed, but like
+// NWS it requires an identifying User-Agent.
+type MetNoProvider struct {
+	httpClient *http.Client
+	cache      Cache
+	sf         singleflight.Group
+}
+
+// NewMetNoProvider builds a provider against the default in-memory LRU cache
+func NewMetNoProvider() *MetNoProvider {
+	return &MetNoProvider{
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		cache: NewLRUCache(500),
+	}
+}
+
+// GetForecast hits the met.no Locationforecast endpoint. Cached and deduped
+// via singleflight the same way NWSProvider is.
+func (p *MetNoProvider) GetForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	forecastKey := cacheKey("metno", lat, lon)
+	if cached, ok := p.cache.Get(forecastKey); ok {
+		var resp WeatherResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return &resp, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(forecastKey, func() (interface{}, error) {
+		return p.fetchForecast(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.(*forecastResult)
+
+	if encoded, err := json.Marshal(result.response); err == nil {
+		p.cache.Set(forecastKey, encoded, result.ttl)
+	}
+
+	return result.response, nil
+}
+
+func (p *MetNoProvider) fetchForecast(ctx context.Context, lat, lon float64) (*forecastResult, error) {
+	defer observeUpstream("forecast")()
+
+	forecastURL := fmt.Sprintf("https://api.met.no/weatherapi/locationforecast/2.0/compact?lat=%.4f&lon=%.4f", lat, lon)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", forecastURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create met.no request: %w", err)
+	}
+
+	// met.no requires an identifying User-Agent, same as NWS
+	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch met.no data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newUpstreamError(resp.StatusCode, fmt.Errorf("met.no API returned status %d", resp.StatusCode))
+	}
+
+	ttl := ttlFromHeaders(resp, metnoForecastCacheTTL)
+
+	var metnoResp metnoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&metnoResp); err != nil {
+		return nil, fmt.Errorf("failed to decode met.no response: %w", err)
+	}
+
+	if len(metnoResp.Properties.Timeseries) == 0 {
+		return nil, fmt.Errorf("no forecast periods available")
+	}
+
+	now := currentMetnoEntry(metnoResp.Properties.Timeseries).Data
+
+	// met.no always returns Celsius
+	temperature := int(now.Instant.Details.AirTemperature*9/5 + 32)
+
+	return &forecastResult{
+		response: &WeatherResponse{
+			Location:    Location{Lat: lat, Lng: lon},
+			Forecast:    now.Next1Hours.Summary.SymbolCode,
+			Temp:        temperature,
+			TempType:    getTempType(float64(temperature)),
+			LastUpdated: time.Now().Format("2006-01-02 15:04:05 MST"),
+		},
+		ttl: ttl,
+	}, nil
+}
+
+// currentMetnoEntry picks the timeseries entry closest to (but not after)
+// now - entries are in ascending time order, so this is the last one at or
+// before now, falling back to the first entry if they're all in the future
+// (clock skew, or a stale cached response)
+func currentMetnoEntry(series []metnoTimeseriesEntry) metnoTimeseriesEntry {
+	now := time.Now()
+	entry := series[0]
+	for _, candidate := range series {
+		if candidate.Time.After(now) {
+			break
+		}
+		entry = candidate
+	}
+	return entry
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Prometheus metrics for the weather API. Registered against the default
+// registry, which the /metrics handler in weather-server.go serves.
+var (
+	requestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_requests_total",
+		Help: "Total HTTP requests handled, by endpoint and response status",
+	}, []string{"endpoint", "status"})
+
+	upstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "weather_upstream_duration_seconds",
+		Help: "Latency of outbound calls to upstream weather APIs",
+	}, []string{"api"})
+
+	cacheHitsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "weather_cache_hits_total",
+		Help: "Total cache hits across all providers",
+	})
+
+	upstreamErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "weather_upstream_errors_total",
+		Help: "Total non-2xx responses from upstream weather APIs, by status code",
+	}, []string{"code"})
+)
+
+// recordRequest increments weather_requests_total for an endpoint/status pair
+func recordRequest(endpoint string, status int) {
+	requestsTotal.WithLabelValues(endpoint, strconv.Itoa(status)).Inc()
+}
+
+// recordUpstreamError increments weather_upstream_errors_total - called from
+// newUpstreamError so every upstream non-2xx response is counted in one place
+func recordUpstreamError(status int) {
+	upstreamErrorsTotal.WithLabelValues(strconv.Itoa(status)).Inc()
+}
+
+// observeUpstream starts a timer for a call to the given upstream api
+// ("points", "forecast" or "alerts"); call the returned func when the call
+// completes to record weather_upstream_duration_seconds
+func observeUpstream(api string) func() {
+	start := time.Now()
+	return func() {
+		upstreamDuration.WithLabelValues(api).Observe(time.Since(start).Seconds())
+	}
+}
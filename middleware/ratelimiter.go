@@ -0,0 +1,153 @@
+// Package middleware provides HTTP middleware shared by the weather service.
+package middleware
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// errorResponse mirrors the main package's ErrorResponse shape so a 429 looks
+// like every other error this API returns
+type errorResponse struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+const (
+	defaultRPM   = 20
+	defaultBurst = 30
+	bucketTTL    = 10 * time.Minute
+	gcInterval   = 5 * time.Minute
+)
+
+// bucket is a token bucket: tokens refill at `rate` tokens/sec up to `burst`
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+	lastSeen   time.Time
+}
+
+// RateLimiter is per-IP token-bucket rate limiting middleware. Configurable
+// via RATE_LIMIT_RPM / RATE_LIMIT_BURST env vars.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64 // tokens per second
+	burst   float64
+	buckets map[string]*bucket
+}
+
+// NewRateLimiter builds a RateLimiter from env vars, defaulting to 20 req/min
+// with a burst of 30, and starts its background bucket GC
+func NewRateLimiter() *RateLimiter {
+	rpm := envInt("RATE_LIMIT_RPM", defaultRPM)
+	burst := envInt("RATE_LIMIT_BURST", defaultBurst)
+
+	rl := &RateLimiter{
+		rate:    float64(rpm) / 60.0,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+
+	go rl.gcLoop()
+
+	return rl
+}
+
+func envInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
+
+// Wrap returns next rate-limited by client IP
+func (rl *RateLimiter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := clientIP(r)
+
+		allowed, retryAfter := rl.allow(ip)
+		if !allowed {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(errorResponse{
+				Error:   "Rate limit exceeded",
+				Message: "Too many requests - slow down",
+			})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allow consumes a token for ip, returning false plus the wait until the next
+// token is available if the bucket is currently empty
+func (rl *RateLimiter) allow(ip string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	now := time.Now()
+	b, found := rl.buckets[ip]
+	if !found {
+		b = &bucket{tokens: rl.burst, lastRefill: now}
+		rl.buckets[ip] = b
+	}
+
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens = min(rl.burst, b.tokens+elapsed*rl.rate)
+	b.lastRefill = now
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / rl.rate * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// gcLoop periodically drops buckets that have gone quiet so memory doesn't
+// grow unbounded with one-off clients
+func (rl *RateLimiter) gcLoop() {
+	ticker := time.NewTicker(gcInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		rl.mu.Lock()
+		cutoff := time.Now().Add(-bucketTTL)
+		for ip, b := range rl.buckets {
+			if b.lastSeen.Before(cutoff) {
+				delete(rl.buckets, ip)
+			}
+		}
+		rl.mu.Unlock()
+	}
+}
+
+// clientIP prefers X-Forwarded-For (set by upstream proxies/load balancers),
+// falling back to RemoteAddr
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		parts := strings.Split(fwd, ",")
+		return strings.TrimSpace(parts[0])
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
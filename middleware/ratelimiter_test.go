@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(rate, burst float64) *RateLimiter {
+	return &RateLimiter{
+		rate:    rate,
+		burst:   burst,
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, rl *RateLimiter)
+	}{
+		{
+			name: "burst cap allows up to burst requests then blocks",
+			run: func(t *testing.T, rl *RateLimiter) {
+				for i := 0; i < 3; i++ {
+					if allowed, _ := rl.allow("1.2.3.4"); !allowed {
+						t.Fatalf("request %d: expected allowed within burst", i)
+					}
+				}
+				if allowed, wait := rl.allow("1.2.3.4"); allowed || wait <= 0 {
+					t.Fatalf("got allowed=%v wait=%v, want allowed=false with positive wait once burst is exhausted", allowed, wait)
+				}
+			},
+		},
+		{
+			name: "tokens refill over time at the configured rate",
+			run: func(t *testing.T, rl *RateLimiter) {
+				for i := 0; i < 3; i++ {
+					rl.allow("1.2.3.4")
+				}
+				if allowed, _ := rl.allow("1.2.3.4"); allowed {
+					t.Fatal("expected bucket to be empty before any refill")
+				}
+
+				// Rewind lastRefill to simulate one second elapsed, refilling 2 tokens at rate=2/s
+				rl.mu.Lock()
+				rl.buckets["1.2.3.4"].lastRefill = time.Now().Add(-time.Second)
+				rl.mu.Unlock()
+
+				if allowed, _ := rl.allow("1.2.3.4"); !allowed {
+					t.Fatal("expected a refilled token to be available after the elapsed second")
+				}
+			},
+		},
+		{
+			name: "per-IP buckets are isolated",
+			run: func(t *testing.T, rl *RateLimiter) {
+				for i := 0; i < 3; i++ {
+					rl.allow("1.1.1.1")
+				}
+				if allowed, _ := rl.allow("1.1.1.1"); allowed {
+					t.Fatal("expected 1.1.1.1's bucket to be exhausted")
+				}
+				if allowed, _ := rl.allow("2.2.2.2"); !allowed {
+					t.Fatal("expected a different IP to have its own untouched bucket")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newTestRateLimiter(2, 3))
+		})
+	}
+}
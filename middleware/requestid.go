@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// requestIDHeader is both the inbound header we'll reuse if a caller already
+// set one (useful behind a gateway that assigns its own IDs) and the header
+// we echo the ID back on
+const requestIDHeader = "X-Request-ID"
+
+type ctxKey int
+
+const requestIDCtxKey ctxKey = iota
+
+// RequestID stashes a request ID in the request context - generating one if
+// the caller didn't supply one via X-Request-ID - and echoes it back on the
+// response so it can be correlated with structured logs downstream
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			id = generateRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDCtxKey, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestIDFromContext returns the request ID stashed by RequestID, or ""
+// if there isn't one (e.g. in a context not derived from a request)
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
+
+func generateRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand failing is effectively unheard of - fall back to a
+		// recognizable placeholder rather than crashing the request
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache TTLs - points->forecast-URL mappings are stable (same NWS grid cell),
+// forecast text updates a handful of times a day, hourly forecasts update
+// roughly hourly
+const (
+	pointsCacheTTL         = 24 * time.Hour
+	forecastCacheTTL       = 10 * time.Minute
+	hourlyForecastCacheTTL = 1 * time.Hour
+)
+
+// PointsResponse NWS API structs - only including fields we actually use
+type PointsResponse struct {
+	Properties struct {
+		Forecast       string `json:"forecast"`
+		ForecastHourly string `json:"forecastHourly"`
+		// GridID, GridX, GridY available but not needed for this use case
+	} `json:"properties"`
+}
+
+// pointsURLs is what we actually cache from a /points lookup - both the
+// daily and hourly forecast URLs, so one points call serves either endpoint
+type pointsURLs struct {
+	Forecast       string `json:"forecast"`
+	ForecastHourly string `json:"forecastHourly"`
+}
+
+type ForecastResponse struct {
+	Properties struct {
+		Periods []ForecastPeriod `json:"periods"`
+	} `json:"properties"`
+}
+
+// ForecastPeriod Simplified - NWS has way more fields ,but we only need as listed below
+type ForecastPeriod struct {
+	Name             string `json:"name"`
+	StartTime        string `json:"startTime"`
+	EndTime          string `json:"endTime"`
+	IsDaytime        bool   `json:"isDaytime"`
+	Temperature      int    `json:"temperature"`
+	TemperatureUnit  string `json:"temperatureUnit"`
+	TemperatureTrend string `json:"temperatureTrend"`
+	WindSpeed        string `json:"windSpeed"`
+	WindDirection    string `json:"windDirection"`
+	ShortForecast    string `json:"shortForecast"`
+	DetailedForecast string `json:"detailedForecast"`
+}
+
+// NWSProvider implements Provider on top of the National Weather Service API.
+// It also exposes GetAlerts, GetDailyPeriods and GetHourlyPeriods, none of
+// which are part of Provider since they're NWS-only capabilities.
+type NWSProvider struct {
+	httpClient *http.Client
+	cache      Cache
+	sf         singleflight.Group
+}
+
+func NewNWSProvider() *NWSProvider {
+	return NewNWSProviderWithCache(NewLRUCache(500))
+}
+
+// NewNWSProviderWithCache lets callers swap in a different Cache (Redis,
+// etc.) instead of the default in-memory LRU
+func NewNWSProviderWithCache(cache Cache) *NWSProvider {
+	// TODO: make timeout configurable via env var
+	return &NWSProvider{
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		cache: cache,
+	}
+}
+
+// forecastResult bundles the parsed response with the TTL it should be cached
+// for, so fetchForecast can honor upstream cache headers
+type forecastResult struct {
+	response *WeatherResponse
+	ttl      time.Duration
+}
+
+// GetForecast hits NWS API - two-step process unfortunately. Both steps are
+// cached (keyed on rounded coordinates) and deduped via singleflight so a
+// burst of requests for the same location only hits NWS once.
+func (p *NWSProvider) GetForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	forecastKey := cacheKey("forecast", lat, lon)
+	if cached, ok := p.cache.Get(forecastKey); ok {
+		var resp WeatherResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return &resp, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(forecastKey, func() (interface{}, error) {
+		urls, err := p.getPointsURLs(ctx, lat, lon)
+		if err != nil {
+			return nil, err
+		}
+		return p.fetchForecast(ctx, urls.Forecast, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.(*forecastResult)
+
+	if encoded, err := json.Marshal(result.response); err == nil {
+		p.cache.Set(forecastKey, encoded, result.ttl)
+	}
+
+	return result.response, nil
+}
+
+// periodsResult bundles a parsed period list with the TTL it should be
+// cached for, so fetchPeriods can honor upstream cache headers
+type periodsResult struct {
+	periods []ForecastPeriod
+	ttl     time.Duration
+}
+
+// GetDailyPeriods returns the full day/night forecast period list for a
+// point (unlike GetForecast, which only returns today's summary)
+func (p *NWSProvider) GetDailyPeriods(ctx context.Context, lat, lon float64) ([]ForecastPeriod, error) {
+	key := cacheKey("forecast-periods", lat, lon)
+	if cached, ok := p.cache.Get(key); ok {
+		var periods []ForecastPeriod
+		if err := json.Unmarshal(cached, &periods); err == nil {
+			return periods, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(key, func() (interface{}, error) {
+		urls, err := p.getPointsURLs(ctx, lat, lon)
+		if err != nil {
+			return nil, err
+		}
+		return p.fetchPeriods(ctx, urls.Forecast, forecastCacheTTL)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.(*periodsResult)
+
+	if encoded, err := json.Marshal(result.periods); err == nil {
+		p.cache.Set(key, encoded, result.ttl)
+	}
+
+	return result.periods, nil
+}
+
+// GetHourlyPeriods returns the hour-by-hour forecast period list for a point
+func (p *NWSProvider) GetHourlyPeriods(ctx context.Context, lat, lon float64) ([]ForecastPeriod, error) {
+	key := cacheKey("forecast-hourly", lat, lon)
+	if cached, ok := p.cache.Get(key); ok {
+		var periods []ForecastPeriod
+		if err := json.Unmarshal(cached, &periods); err == nil {
+			return periods, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(key, func() (interface{}, error) {
+		urls, err := p.getPointsURLs(ctx, lat, lon)
+		if err != nil {
+			return nil, err
+		}
+		if urls.ForecastHourly == "" {
+			return nil, fmt.Errorf("no hourly forecast URL available for this location")
+		}
+		return p.fetchPeriods(ctx, urls.ForecastHourly, hourlyForecastCacheTTL)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := v.(*periodsResult)
+
+	if encoded, err := json.Marshal(result.periods); err == nil {
+		p.cache.Set(key, encoded, result.ttl)
+	}
+
+	return result.periods, nil
+}
+
+// getPointsURLs resolves lat/lon to forecast URLs via the NWS /points
+// lookup, caching the mapping since the grid assignment doesn't change
+func (p *NWSProvider) getPointsURLs(ctx context.Context, lat, lon float64) (*pointsURLs, error) {
+	pointsKey := cacheKey("points", lat, lon)
+	if cached, ok := p.cache.Get(pointsKey); ok {
+		var urls pointsURLs
+		if err := json.Unmarshal(cached, &urls); err == nil {
+			return &urls, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(pointsKey, func() (interface{}, error) {
+		return p.fetchPoints(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	urls := v.(*pointsURLs)
+	if encoded, err := json.Marshal(urls); err == nil {
+		p.cache.Set(pointsKey, encoded, pointsCacheTTL)
+	}
+	return urls, nil
+}
+
+func (p *NWSProvider) fetchPoints(ctx context.Context, lat, lon float64) (*pointsURLs, error) {
+	defer observeUpstream("points")()
+
+	pointsURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", pointsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create points request: %w", err)
+	}
+
+	// NWS returns 403 without User-Agent
+	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch points data: %w", err)
+	}
+	defer resp.Body.Close()
+	//would create error handling here
+
+	if resp.StatusCode != http.StatusOK {
+		// TODO: better error handling for different status codes
+		return nil, newUpstreamError(resp.StatusCode, fmt.Errorf("NWS points API returned status %d", resp.StatusCode))
+	}
+
+	var pointsResp PointsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&pointsResp); err != nil {
+		return nil, fmt.Errorf("failed to decode points response: %w", err)
+	}
+
+	if pointsResp.Properties.Forecast == "" {
+		return nil, fmt.Errorf("no forecast URL available for this location")
+	}
+
+	return &pointsURLs{
+		Forecast:       pointsResp.Properties.Forecast,
+		ForecastHourly: pointsResp.Properties.ForecastHourly,
+	}, nil
+}
+
+// fetchPeriods fetches and decodes a forecast period list from either the
+// daily or hourly NWS forecast URL - shared by fetchForecast, GetDailyPeriods
+// and GetHourlyPeriods
+func (p *NWSProvider) fetchPeriods(ctx context.Context, forecastURL string, fallbackTTL time.Duration) (*periodsResult, error) {
+	defer observeUpstream("forecast")()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", forecastURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forecast request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch forecast data: %w", err)
+	}
+	defer resp.Body.Close()
+	//would create error handling here
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newUpstreamError(resp.StatusCode, fmt.Errorf("NWS forecast API returned status %d", resp.StatusCode))
+	}
+
+	ttl := ttlFromHeaders(resp, fallbackTTL)
+
+	var forecastResp ForecastResponse
+	if err := json.NewDecoder(resp.Body).Decode(&forecastResp); err != nil {
+		return nil, fmt.Errorf("failed to decode forecast response: %w", err)
+	}
+
+	return &periodsResult{periods: forecastResp.Properties.Periods, ttl: ttl}, nil
+}
+
+// pickTodayPeriod picks the period GetForecast summarizes - the first one
+// whose name mentions "today" or that is a daytime period, falling back to
+// whatever's first
+func pickTodayPeriod(periods []ForecastPeriod) *ForecastPeriod {
+	for i := range periods {
+		period := &periods[i]
+		if strings.Contains(strings.ToLower(period.Name), "today") || period.IsDaytime {
+			return period
+		}
+	}
+
+	if len(periods) > 0 {
+		return &periods[0]
+	}
+
+	return nil
+}
+
+// Split this out to keep GetForecast readable
+func (p *NWSProvider) fetchForecast(ctx context.Context, forecastURL string, lat, lon float64) (*forecastResult, error) {
+	pr, err := p.fetchPeriods(ctx, forecastURL, forecastCacheTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	todayPeriod := pickTodayPeriod(pr.periods)
+	if todayPeriod == nil {
+		return nil, fmt.Errorf("no forecast periods available")
+	}
+
+	// NWS usually returns F but just in case
+	temperature := todayPeriod.Temperature
+	if todayPeriod.TemperatureUnit == "C" {
+		temperature = int(float64(temperature)*9/5 + 32)
+	}
+
+	tempType := getTempType(float64(temperature))
+
+	return &forecastResult{
+		response: &WeatherResponse{
+			Location:    Location{Lat: lat, Lng: lon},
+			Forecast:    todayPeriod.ShortForecast,
+			Temp:        temperature,
+			TempType:    tempType,
+			Details:     todayPeriod.DetailedForecast,
+			LastUpdated: time.Now().Format("2006-01-02 15:04:05 MST"),
+		},
+		ttl: pr.ttl,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"golang.org/x/sync/singleflight"
+)
+
+// owmForecastCacheTTL OpenWeatherMap's free tier updates current conditions
+// every ~10 minutes, so there's no point caching any longer than that
+const owmForecastCacheTTL = 10 * time.Minute
+
+// owmCurrentResponse OpenWeatherMap "current weather" API - only including
+// fields we actually use
+type owmCurrentResponse struct {
+	Weather []struct {
+		Main        string `json:"main"`
+		Description string `json:"description"`
+	} `json:"weather"`
+	Main struct {
+		Temp float64 `json:"temp"`
+	} `json:"main"`
+}
+
+// OpenWeatherMapProvider implements Provider on top of the OpenWeatherMap
+// current weather API.
+type OpenWeatherMapProvider struct {
+	apiKey     string
+	httpClient *http.Client
+	cache      Cache
+	sf         singleflight.Group
+}
+
+// NewOpenWeatherMapProvider builds a provider using apiKey (from
+// WEATHER_API_KEY) against the default in-memory LRU cache
+func NewOpenWeatherMapProvider(apiKey string) *OpenWeatherMapProvider {
+	return &OpenWeatherMapProvider{
+		apiKey: apiKey,
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		cache: NewLRUCache(500),
+	}
+}
+
+// GetForecast hits the OpenWeatherMap current weather endpoint. Cached and
+// deduped via singleflight the same way NWSProvider is.
+func (p *OpenWeatherMapProvider) GetForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	forecastKey := cacheKey("owm", lat, lon)
+	if cached, ok := p.cache.Get(forecastKey); ok {
+		var resp WeatherResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			return &resp, nil
+		}
+	}
+
+	v, err, _ := p.sf.Do(forecastKey, func() (interface{}, error) {
+		return p.fetchForecast(ctx, lat, lon)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := v.(*WeatherResponse)
+
+	if encoded, err := json.Marshal(resp); err == nil {
+		p.cache.Set(forecastKey, encoded, owmForecastCacheTTL)
+	}
+
+	return resp, nil
+}
+
+func (p *OpenWeatherMapProvider) fetchForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	defer observeUpstream("forecast")()
+
+	if p.apiKey == "" {
+		return nil, fmt.Errorf("WEATHER_API_KEY is required for the openweathermap provider")
+	}
+
+	forecastURL := fmt.Sprintf(
+		"https://api.openweathermap.org/data/2.5/weather?lat=%.4f&lon=%.4f&units=imperial&appid=%s",
+		lat, lon, p.apiKey,
+	)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", forecastURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OpenWeatherMap request: %w", err)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OpenWeatherMap data: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newUpstreamError(resp.StatusCode, fmt.Errorf("OpenWeatherMap API returned status %d", resp.StatusCode))
+	}
+
+	var owmResp owmCurrentResponse
+	if err := json.NewDecoder(resp.Body).Decode(&owmResp); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenWeatherMap response: %w", err)
+	}
+
+	var short string
+	if len(owmResp.Weather) > 0 {
+		short = owmResp.Weather[0].Description
+	}
+
+	temperature := int(owmResp.Main.Temp)
+
+	return &WeatherResponse{
+		Location:    Location{Lat: lat, Lng: lon},
+		Forecast:    short,
+		Temp:        temperature,
+		TempType:    getTempType(float64(temperature)),
+		LastUpdated: time.Now().Format("2006-01-02 15:04:05 MST"),
+	}, nil
+}
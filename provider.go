@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"os"
+)
+
+// Provider abstracts over weather data sources so WeatherHandler doesn't
+// care whether it's talking to NWS, OpenWeatherMap, or met.no. Alerts are
+// deliberately not part of this interface - they're an NWS-only capability,
+// so callers type-assert to *NWSProvider when they need them (see
+// WeatherHandler.nws).
+type Provider interface {
+	GetForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error)
+}
+
+// NewProviders builds the primary provider from WEATHER_PROVIDER /
+// WEATHER_API_KEY, plus a fallback to fall back on when the primary errors -
+// including when the primary is NWS and the coordinates are outside its
+// US-only coverage. Both NWS and met.no are keyless, so whichever of the two
+// isn't already the primary is used as the fallback; that keeps the default
+// (no WEATHER_PROVIDER set) working worldwide out of the box.
+func NewProviders() (primary Provider, fallback Provider) {
+	switch os.Getenv("WEATHER_PROVIDER") {
+	case "openweathermap", "owm":
+		return NewOpenWeatherMapProvider(os.Getenv("WEATHER_API_KEY")), NewNWSProvider()
+	case "metno", "met.no":
+		return NewMetNoProvider(), NewNWSProvider()
+	default:
+		return NewNWSProvider(), NewMetNoProvider()
+	}
+}
+
+// FallbackProvider tries primary first and only calls fallback if primary
+// errors - keeps the happy path a single upstream call.
+type FallbackProvider struct {
+	primary  Provider
+	fallback Provider
+}
+
+// NewFallbackProvider wraps primary with fallback. fallback may be nil, in
+// which case FallbackProvider just delegates straight to primary.
+func NewFallbackProvider(primary, fallback Provider) *FallbackProvider {
+	return &FallbackProvider{primary: primary, fallback: fallback}
+}
+
+// GetForecast tries the primary provider first, falling back only on error
+func (fp *FallbackProvider) GetForecast(ctx context.Context, lat, lon float64) (*WeatherResponse, error) {
+	resp, err := fp.primary.GetForecast(ctx, lat, lon)
+	if err == nil || fp.fallback == nil {
+		return resp, err
+	}
+
+	return fp.fallback.GetForecast(ctx, lat, lon)
+}
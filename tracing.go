@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// initTracer wires up an OTLP/HTTP trace exporter when
+// OTEL_EXPORTER_OTLP_ENDPOINT is set. Otherwise it leaves the global tracer
+// provider as otel's no-op default, so otelhttp's spans cost nothing and
+// local dev doesn't need a collector running. Returns a shutdown func that
+// flushes any buffered spans, safe to call even when tracing was never set up.
+func initTracer(ctx context.Context) func(context.Context) error {
+	noop := func(context.Context) error { return nil }
+
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" {
+		return noop
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		slog.Error("failed to set up OTLP exporter, tracing disabled", "error", err)
+		return noop
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(semconv.ServiceName("weather-api")))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown
+}
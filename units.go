@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// windSpeedPattern matches the NWS windSpeed shape, e.g. "10 mph" or the
+// gusty "10 to 20 mph"
+var windSpeedPattern = regexp.MustCompile(`^(\d+)(?: to (\d+))? mph$`)
+
+// convertTemp converts a Fahrenheit temperature to the requested units.
+// units is expected to already be normalized to "imperial" or "metric".
+func convertTemp(tempF int, units string) int {
+	if units == "metric" {
+		return int((float64(tempF) - 32) * 5 / 9)
+	}
+	return tempF
+}
+
+// convertWindSpeed converts an NWS windSpeed string (always mph) to km/h
+// when units is "metric". Left as-is if it doesn't match the expected shape.
+func convertWindSpeed(windSpeed string, units string) string {
+	if units != "metric" {
+		return windSpeed
+	}
+
+	m := windSpeedPattern.FindStringSubmatch(windSpeed)
+	if m == nil {
+		return windSpeed
+	}
+
+	low := mphToKph(m[1])
+	if m[2] == "" {
+		return fmt.Sprintf("%d km/h", low)
+	}
+
+	high := mphToKph(m[2])
+	return fmt.Sprintf("%d to %d km/h", low, high)
+}
+
+func mphToKph(mph string) int {
+	n, err := strconv.Atoi(mph)
+	if err != nil {
+		return 0
+	}
+	return int(float64(n) * 1.60934)
+}
+
+// normalizeUnits defaults an empty/unrecognized units param to imperial
+func normalizeUnits(units string) string {
+	if units == "metric" {
+		return "metric"
+	}
+	return "imperial"
+}
@@ -1,30 +1,38 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
 	"strconv"
-	"strings"
+	"syscall"
 	"time"
+
+	"github.com/GergJack/JackHenryProject/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // Quick weather API for the take-home assignment
 // Shortcuts taken due to time constraints:
-// - No tests 
-// - No graceful shutdown handling
-// - Basic error handling **would use structured logging)
-// - No rate limiting or caching
+// - Tests only for the cache and rate limiter
 // - Hardcoded timeout values
 
+// defaultShutdownTimeout is how long we wait for in-flight requests to drain
+// before forcing the server closed, overridable via SHUTDOWN_TIMEOUT (seconds)
+const defaultShutdownTimeout = 15 * time.Second
+
 type WeatherResponse struct {
-	Location    Location `json:"location"`
-	Forecast    string   `json:"forecast"`
-	Temp        int      `json:"temp_f"`
-	TempType    string   `json:"temp_type"`
-	Details     string   `json:"details,omitempty"`
-	LastUpdated string   `json:"last_updated"`
+	Location    Location       `json:"location"`
+	Forecast    string         `json:"forecast"`
+	Temp        int            `json:"temp_f"`
+	TempType    string         `json:"temp_type"`
+	Details     string         `json:"details,omitempty"`
+	LastUpdated string         `json:"last_updated"`
+	Alerts      []AlertSummary `json:"alerts,omitempty"`
 }
 
 type Location struct {
@@ -37,142 +45,33 @@ type ErrorResponse struct {
 	Message string `json:"message"`
 }
 
-// PointsResponse NWS API structs - only including fields we actually use
-type PointsResponse struct {
-	Properties struct {
-		Forecast string `json:"forecast"`
-		// GridID, GridX, GridY available but not needed for this use case
-	} `json:"properties"`
-}
-
-type ForecastResponse struct {
-	Properties struct {
-		Periods []ForecastPeriod `json:"periods"`
-	} `json:"properties"`
-}
-
-// ForecastPeriod Simplified - NWS has way more fields ,but we only need as listed below
-type ForecastPeriod struct {
-	Name             string `json:"name"`
-	IsDaytime        bool   `json:"isDaytime"`
-	Temperature      int    `json:"temperature"`
-	TemperatureUnit  string `json:"temperatureUnit"`
-	ShortForecast    string `json:"shortForecast"`
-	DetailedForecast string `json:"detailedForecast"`
-}
-
-type WeatherService struct {
-	httpClient *http.Client
-}
-
-func NewWeatherService() *WeatherService {
-	// TODO: make timeout configurable via env var
-	return &WeatherService{
-		httpClient: &http.Client{Timeout: 10 * time.Second},
-	}
-}
-
-// GetForecast hits NWS API - two-step process unfortunately
-func (ws *WeatherService) GetForecast(lat, lon float64) (*WeatherResponse, error) {
-	// Step 1: Get the forecast URL from coordinates
-	pointsURL := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lon)
-
-	req, err := http.NewRequest("GET", pointsURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create points request: %w", err)
-	}
-
-	// NWS returns 403 without User-Agent
-	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
-
-	resp, err := ws.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch points data: %w", err)
-	}
-	defer resp.Body.Close()
-	//would create error handling here
-
-	if resp.StatusCode != http.StatusOK {
-		// TODO: better error handling for different status codes
-		return nil, fmt.Errorf("NWS points API returned status %d", resp.StatusCode)
-	}
-
-	var pointsResp PointsResponse
-	if err := json.NewDecoder(resp.Body).Decode(&pointsResp); err != nil {
-		return nil, fmt.Errorf("failed to decode points response: %w", err)
-	}
+// parseLatLon extracts and validates the lat/lon query params shared by
+// every coordinate-based endpoint. ok is false if they're missing, not
+// numbers, or out of range, in which case status/errTitle/errMsg are ready
+// to pass straight to the caller's sendError.
+func parseLatLon(r *http.Request) (lat, lon float64, status int, errTitle, errMsg string, ok bool) {
+	latStr := r.URL.Query().Get("lat")
+	lonStr := r.URL.Query().Get("lon")
 
-	// Step 2: Get actual forecast
-	forecastURL := pointsResp.Properties.Forecast
-	if forecastURL == "" {
-		return nil, fmt.Errorf("no forecast URL available for this location")
+	if latStr == "" || lonStr == "" {
+		return 0, 0, http.StatusBadRequest, "Missing coords", "Need both lat and lon params", false
 	}
 
-	return ws.fetchForecast(forecastURL, lat, lon)
-}
-
-// Split this out to keep main function readable
-func (ws *WeatherService) fetchForecast(forecastURL string, lat, lon float64) (*WeatherResponse, error) {
-	req, err := http.NewRequest("GET", forecastURL, nil)
+	lat, err := strconv.ParseFloat(latStr, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create forecast request: %w", err)
+		return 0, 0, http.StatusBadRequest, "Bad latitude", "Must be a number", false
 	}
 
-	req.Header.Set("User-Agent", "WeatherApp/1.0 (contact@example.com)")
-
-	resp, err := ws.httpClient.Do(req)
+	lon, err = strconv.ParseFloat(lonStr, 64)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch forecast data: %w", err)
-	}
-	defer resp.Body.Close()
-	//would create error handling here
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("NWS forecast API returned status %d", resp.StatusCode)
-	}
-
-	var forecastResp ForecastResponse
-	if err := json.NewDecoder(resp.Body).Decode(&forecastResp); err != nil {
-		return nil, fmt.Errorf("failed to decode forecast response: %w", err)
-	}
-
-	// Find today's forecast - usually the first daytime period
-	var todayPeriod *ForecastPeriod
-	for i := range forecastResp.Properties.Periods {
-		period := &forecastResp.Properties.Periods[i]
-		if strings.Contains(strings.ToLower(period.Name), "today") ||
-			(todayPeriod == nil && period.IsDaytime) {
-			//would create error handling here
-			todayPeriod = period
-			break
-		}
-	}
-
-	if todayPeriod == nil && len(forecastResp.Properties.Periods) > 0 {
-		// Fallback to first period if no "today" found
-		todayPeriod = &forecastResp.Properties.Periods[0]
-	}
-
-	if todayPeriod == nil {
-		return nil, fmt.Errorf("no forecast periods available")
+		return 0, 0, http.StatusBadRequest, "Bad longitude", "Must be a number", false
 	}
 
-	// NWS usually returns F but just in case
-	temperature := todayPeriod.Temperature
-	if todayPeriod.TemperatureUnit == "C" {
-		temperature = int(float64(temperature)*9/5 + 32)
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return 0, 0, http.StatusBadRequest, "Invalid coords", "Check your lat/lon values", false
 	}
 
-	tempType := getTempType(float64(temperature))
-
-	return &WeatherResponse{
-		Location:    Location{Lat: lat, Lng: lon},
-		Forecast:    todayPeriod.ShortForecast,
-		Temp:        temperature,
-		TempType:    tempType,
-		Details:     todayPeriod.DetailedForecast,
-		LastUpdated: time.Now().Format("2006-01-02 15:04:05 MST"),
-	}, nil
+	return lat, lon, 0, "", "", true
 }
 
 // Simple temp bucketing based on our requirements
@@ -189,64 +88,93 @@ func getTempType(temp float64) string {
 
 // WeatherHandler handles HTTP requests for weather forecasts
 type WeatherHandler struct {
-	weatherService *WeatherService
+	provider Provider
+	// nws is non-nil when an NWSProvider is part of the active provider chain -
+	// alerts and the multi-day/hourly forecast endpoints are NWS-only features,
+	// so other providers don't support them
+	nws *NWSProvider
 }
 
-// NewWeatherHandler creates a new weather handler
+// NewWeatherHandler creates a new weather handler using the provider(s)
+// configured via WEATHER_PROVIDER/WEATHER_API_KEY
 func NewWeatherHandler() *WeatherHandler {
-	return &WeatherHandler{
-		weatherService: NewWeatherService(),
+	primary, fallback := NewProviders()
+
+	wh := &WeatherHandler{provider: NewFallbackProvider(primary, fallback)}
+
+	if nws, ok := primary.(*NWSProvider); ok {
+		wh.nws = nws
+	} else if nws, ok := fallback.(*NWSProvider); ok {
+		wh.nws = nws
 	}
+
+	return wh
 }
 
 // ServeHTTP handles the /weather endpoint
 func (wh *WeatherHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	reqID := middleware.RequestIDFromContext(r.Context())
+
 	if r.Method != http.MethodGet {
 		wh.sendError(w, http.StatusMethodNotAllowed, "Method not allowed", "Only GET supported")
 		return
 	}
 
-	// Get coords from query params
-	latStr := r.URL.Query().Get("lat")
-	lonStr := r.URL.Query().Get("lon")
-
-	if latStr == "" || lonStr == "" {
-		wh.sendError(w, http.StatusBadRequest, "Missing coords", "Need both lat and lon params")
-		return
-	}
-
-	lat, err := strconv.ParseFloat(latStr, 64)
-	if err != nil {
-		wh.sendError(w, http.StatusBadRequest, "Bad latitude", "Must be a number")
+	lat, lon, status, errTitle, errMsg, ok := parseLatLon(r)
+	if !ok {
+		wh.sendError(w, status, errTitle, errMsg)
 		return
 	}
 
-	lon, err := strconv.ParseFloat(lonStr, 64)
+	forecast, err := wh.provider.GetForecast(r.Context(), lat, lon)
 	if err != nil {
-		wh.sendError(w, http.StatusBadRequest, "Bad longitude", "Must be a number")
-		return
-	}
-
-	// Basic validation
-	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
-		wh.sendError(w, http.StatusBadRequest, "Invalid coords", "Check your lat/lon values")
+		slog.Error("weather request failed",
+			"request_id", reqID,
+			"lat", lat,
+			"lon", lon,
+			"upstream_status", upstreamStatus(err),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"error", err,
+		)
+		wh.sendError(w, http.StatusInternalServerError, "API error", "Could not get forecast")
 		return
 	}
 
-	forecast, err := wh.weatherService.GetForecast(lat, lon)
-	if err != nil {
-		log.Printf("Forecast error: %v", err)
-		wh.sendError(w, http.StatusInternalServerError, "API error", "Could not get forecast")
-		return
+	// Alerts are a nice-to-have on top of the forecast - don't fail the whole
+	// request just because the alerts lookup had trouble (or isn't available
+	// for the active provider)
+	if wh.nws != nil {
+		alerts, err := wh.nws.GetAlerts(r.Context(), lat, lon)
+		if err != nil {
+			slog.Error("alerts lookup failed",
+				"request_id", reqID,
+				"lat", lat,
+				"lon", lon,
+				"upstream_status", upstreamStatus(err),
+				"error", err,
+			)
+		} else {
+			forecast.Alerts = alerts
+		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(forecast)
 	//would create error handling here
+
+	recordRequest("/weather", http.StatusOK)
+	slog.Info("weather request served",
+		"request_id", reqID,
+		"lat", lat,
+		"lon", lon,
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
 }
 
 // sendError writes an error response
 func (wh *WeatherHandler) sendError(w http.ResponseWriter, statusCode int, error, message string) {
+	recordRequest("/weather", statusCode)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(ErrorResponse{Error: error, Message: message})
@@ -262,14 +190,28 @@ func healthHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func main() {
+	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
+
+	// No-op unless OTEL_EXPORTER_OTLP_ENDPOINT is set
+	shutdownTracer := initTracer(context.Background())
+	defer shutdownTracer(context.Background())
+
 	// Quick setup - in production would use proper config management
 	weatherHandler := NewWeatherHandler()
-
-	http.Handle("/weather", weatherHandler)
-	http.HandleFunc("/health", healthHandler)
+	alertsHandler := NewAlertsHandler(weatherHandler.nws)
+	forecastHandler := NewForecastHandler(weatherHandler.nws)
+	hourlyForecastHandler := NewHourlyForecastHandler(weatherHandler.nws)
+
+	mux := http.NewServeMux()
+	mux.Handle("/weather", otelhttp.NewHandler(weatherHandler, "weather"))
+	mux.Handle("/alerts", otelhttp.NewHandler(alertsHandler, "alerts"))
+	mux.Handle("/forecast", otelhttp.NewHandler(forecastHandler, "forecast"))
+	mux.Handle("/forecast/hourly", otelhttp.NewHandler(hourlyForecastHandler, "forecast.hourly"))
+	mux.HandleFunc("/health", healthHandler)
+	mux.Handle("/metrics", promhttp.Handler())
 
 	// Basic info endpoint
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/" {
 			http.NotFound(w, r)
 			return
@@ -277,8 +219,15 @@ func main() {
 		w.Header().Set("Content-Type", "application/json")
 		// Hardcoded for now - would move to config
 		info := map[string]interface{}{
-			"name":      "Weather API",
-			"endpoints": []string{"/weather?lat=X&lon=Y", "/health"},
+			"name": "Weather API",
+			"endpoints": []string{
+				"/weather?lat=X&lon=Y",
+				"/alerts?lat=X&lon=Y",
+				"/forecast?lat=X&lon=Y&days=N&units=imperial|metric&verbose=true",
+				"/forecast/hourly?lat=X&lon=Y&hours=N&units=imperial|metric&verbose=true",
+				"/health",
+				"/metrics",
+			},
 			"temp_ranges": map[string]string{
 				"cold":     "≤60.7°F",
 				"moderate": "60.8-89.4°F",
@@ -289,8 +238,48 @@ func main() {
 		//would create error handling here
 	})
 
-	port := ":8080"
-	log.Printf("Weather server listening on %s", port)
-	// TODO: add graceful shutdown, signal handling
-	log.Fatal(http.ListenAndServe(port, nil))
+	// Rate limit everything - protects us and protects NWS from abusive clients
+	rateLimiter := middleware.NewRateLimiter()
+	handler := middleware.RequestID(rateLimiter.Wrap(mux))
+
+	server := &http.Server{
+		Addr:    ":8080",
+		Handler: handler,
+	}
+
+	go func() {
+		slog.Info("weather server listening", "addr", server.Addr)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			slog.Error("server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	stop()
+
+	slog.Info("shutdown signal received, draining in-flight requests")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), envDuration("SHUTDOWN_TIMEOUT", defaultShutdownTimeout))
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("graceful shutdown failed", "error", err)
+	}
+}
+
+// envDuration reads key as a whole number of seconds, falling back to
+// fallback if it's missing or not a positive integer
+func envDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	secs, err := strconv.Atoi(v)
+	if err != nil || secs <= 0 {
+		return fallback
+	}
+	return time.Duration(secs) * time.Second
 }